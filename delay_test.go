@@ -0,0 +1,95 @@
+/*
+   Copyright 2021, Subba Reddy Veeramreddy
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package mockhttpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Simulated Errors and Latency", func() {
+	var responseMap ResponseConfigMap
+	var client *http.Client
+	var resp1 *http.Response
+
+	BeforeEach(func() {
+		resp1 = &http.Response{StatusCode: 200, Header: http.Header{}}
+		responseMap = make(ResponseConfigMap)
+	})
+
+	It("Returns a synthetic transport error instead of a response", func() {
+		responseMap[http.MethodGet] = &ResponseConfig{Error: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+		client, _ = NewMockHTTPClient(responseMap)
+
+		req, _ := http.NewRequest("GET", "http://1.2.3.4/flaky", nil)
+		resp, err := client.Do(req)
+		Expect(err).NotTo(BeNil())
+		Expect(resp).To(BeNil())
+	})
+
+	It("Waits out Delay before serving the response", func() {
+		responseMap[http.MethodGet] = &ResponseConfig{Responses: []*http.Response{resp1}, Delay: 20 * time.Millisecond}
+		client, _ = NewMockHTTPClient(responseMap)
+
+		req, _ := http.NewRequest("GET", "http://1.2.3.4/slow", nil)
+		start := time.Now()
+		resp, err := client.Do(req)
+		Expect(err).To(BeNil())
+		Expect(resp).To(Equal(resp1))
+		Expect(time.Since(start)).To(BeNumerically(">=", 20*time.Millisecond))
+	})
+
+	It("Varies the delay per attempt via DelayFunc", func() {
+		var seen []time.Duration
+		responseMap[http.MethodGet] = &ResponseConfig{
+			Responses: []*http.Response{resp1, resp1},
+			DelayFunc: func(attempt int) time.Duration {
+				d := time.Duration(attempt) * 5 * time.Millisecond
+				seen = append(seen, d)
+				return d
+			},
+		}
+		client, _ = NewMockHTTPClient(responseMap)
+
+		req, _ := http.NewRequest("GET", "http://1.2.3.4/slow", nil)
+		_, _ = client.Do(req)
+		_, _ = client.Do(req)
+		Expect(seen).To(Equal([]time.Duration{5 * time.Millisecond, 10 * time.Millisecond}))
+	})
+
+	It("Cancels the delay early when the request context is done", func() {
+		responseMap[http.MethodGet] = &ResponseConfig{Responses: []*http.Response{resp1}, Delay: time.Second}
+		client, _ = NewMockHTTPClient(responseMap)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequest("GET", "http://1.2.3.4/slow", nil)
+		req = req.WithContext(ctx)
+
+		start := time.Now()
+		resp, err := client.Do(req)
+		Expect(err).NotTo(BeNil())
+		Expect(resp).To(BeNil())
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+})
@@ -0,0 +1,199 @@
+/*
+   Copyright 2021, Subba Reddy Veeramreddy
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestFixtures(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fixtures Suite")
+}
+
+const harJSON = `{
+  "log": {
+    "entries": [
+      {
+        "request": {"method": "GET", "url": "http://api.example.com/users/1", "headers": []},
+        "response": {"status": 200, "headers": [{"name": "Content-Type", "value": "application/json"}], "content": {"text": "{\"id\":1}"}}
+      },
+      {
+        "request": {"method": "GET", "url": "http://api.example.com/health", "headers": []},
+        "response": {"status": 200, "headers": [], "content": {"text": "ok"}}
+      }
+    ]
+  }
+}`
+
+const harQueryJSON = `{
+  "log": {
+    "entries": [
+      {
+        "request": {"method": "GET", "url": "http://api.example.com/users?page=1", "headers": []},
+        "response": {"status": 200, "headers": [], "content": {"text": "page-1"}}
+      },
+      {
+        "request": {"method": "GET", "url": "http://api.example.com/users?page=2", "headers": []},
+        "response": {"status": 200, "headers": [], "content": {"text": "page-2"}}
+      }
+    ]
+  }
+}`
+
+const openAPIMultiContentJSON = `{
+  "paths": {
+    "/users/{id}": {
+      "get": {
+        "responses": {
+          "200": {
+            "content": {
+              "application/problem+json": {"example": {"id": -1}},
+              "application/vnd.custom+json": {"example": {"id": -2}},
+              "application/json": {
+                "examples": {
+                  "zulu": {"value": {"id": 1, "name": "zulu-example"}},
+                  "alpha": {"value": {"id": 1, "name": "Ada"}}
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const openAPIJSON = `{
+  "paths": {
+    "/users/{id}": {
+      "get": {
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {"example": {"id": 1, "name": "Ada"}}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func writeTempFile(contents string) string {
+	dir, err := ioutil.TempDir("", "fixtures-test")
+	Expect(err).To(BeNil())
+	path := filepath.Join(dir, "fixture.json")
+	Expect(ioutil.WriteFile(path, []byte(contents), 0644)).To(Succeed())
+	return path
+}
+
+var _ = Describe("HAR fixture loading", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = writeTempFile(harJSON)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(filepath.Dir(path))
+	})
+
+	It("Replays recorded responses grouped by method and path", func() {
+		client, err := NewMockHTTPClientFromHAR(path)
+		Expect(err).To(BeNil())
+
+		req, _ := http.NewRequest("GET", "http://1.2.3.4/users/1", nil)
+		resp, err := client.Do(req)
+		Expect(err).To(BeNil())
+		Expect(resp.StatusCode).To(Equal(200))
+
+		req, _ = http.NewRequest("GET", "http://1.2.3.4/health", nil)
+		resp, err = client.Do(req)
+		Expect(err).To(BeNil())
+		Expect(resp.StatusCode).To(Equal(200))
+	})
+
+	It("Replays entries sharing a method and path independently by query string", func() {
+		queryPath := writeTempFile(harQueryJSON)
+		defer os.RemoveAll(filepath.Dir(queryPath))
+
+		client, err := NewMockHTTPClientFromHAR(queryPath)
+		Expect(err).To(BeNil())
+
+		req, _ := http.NewRequest("GET", "http://1.2.3.4/users?page=2", nil)
+		resp, err := client.Do(req)
+		Expect(err).To(BeNil())
+		body, _ := ioutil.ReadAll(resp.Body)
+		Expect(string(body)).To(Equal("page-2"))
+
+		req, _ = http.NewRequest("GET", "http://1.2.3.4/users?page=1", nil)
+		resp, err = client.Do(req)
+		Expect(err).To(BeNil())
+		body, _ = ioutil.ReadAll(resp.Body)
+		Expect(string(body)).To(Equal("page-1"))
+	})
+})
+
+var _ = Describe("OpenAPI fixture loading", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = writeTempFile(openAPIJSON)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(filepath.Dir(path))
+	})
+
+	It("Synthesizes a response from the operation's example", func() {
+		client, err := NewMockHTTPClientFromOpenAPI(path)
+		Expect(err).To(BeNil())
+
+		req, _ := http.NewRequest("GET", "http://1.2.3.4/users/42", nil)
+		resp, err := client.Do(req)
+		Expect(err).To(BeNil())
+		Expect(resp.StatusCode).To(Equal(200))
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		Expect(string(body)).To(Equal(`{"id":1,"name":"Ada"}`))
+	})
+
+	It("Deterministically picks application/json and its lowest-named example every time", func() {
+		multiPath := writeTempFile(openAPIMultiContentJSON)
+		defer os.RemoveAll(filepath.Dir(multiPath))
+
+		for i := 0; i < 20; i++ {
+			client, err := NewMockHTTPClientFromOpenAPI(multiPath)
+			Expect(err).To(BeNil())
+
+			req, _ := http.NewRequest("GET", "http://1.2.3.4/users/42", nil)
+			resp, err := client.Do(req)
+			Expect(err).To(BeNil())
+
+			body, _ := ioutil.ReadAll(resp.Body)
+			Expect(string(body)).To(Equal(`{"id":1,"name":"Ada"}`))
+		}
+	})
+})
@@ -0,0 +1,122 @@
+/*
+   Copyright 2021, Subba Reddy Veeramreddy
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package fixtures loads mockhttpclient response maps from external capture
+// and spec formats, so tests can replay recorded traffic or be driven
+// straight from an API contract instead of hand-building every response.
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/subbuv26/mockhttpclient"
+)
+
+// harFile is the subset of the HTTP Archive (HAR) 1.2 format this loader
+// understands: a flat log of request/response entries.
+type harFile struct {
+	Log struct {
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harEntry struct {
+	Request struct {
+		Method  string         `json:"method"`
+		URL     string         `json:"url"`
+		Headers []harNameValue `json:"headers"`
+	} `json:"request"`
+	Response struct {
+		Status  int            `json:"status"`
+		Headers []harNameValue `json:"headers"`
+		Content struct {
+			Text string `json:"text"`
+		} `json:"content"`
+	} `json:"response"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// NewMockHTTPClientFromHAR builds a mock client from a HAR JSON export
+// (e.g. captured from a browser devtools "Save all as HAR" or a proxy like
+// mitmproxy). Entries are grouped by method+URL (path and query string) into
+// RequestMatcher keys, so "GET /users?page=1" and "GET /users?page=2" replay
+// independently even though they share a method and path.
+func NewMockHTTPClientFromHAR(path string) (*http.Client, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fixtures: reading HAR file: %w", err)
+	}
+
+	var har harFile
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("fixtures: parsing HAR file: %w", err)
+	}
+
+	responseMap := make(mockhttpclient.ResponseConfigMap)
+	for _, entry := range har.Log.Entries {
+		u, err := url.Parse(entry.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("fixtures: parsing HAR entry URL %q: %w", entry.Request.URL, err)
+		}
+
+		header := make(http.Header, len(entry.Response.Headers))
+		for _, h := range entry.Response.Headers {
+			header.Add(h.Name, h.Value)
+		}
+
+		resp := &http.Response{
+			StatusCode: entry.Response.Status,
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(entry.Response.Content.Text))),
+		}
+
+		query := u.Query()
+		// query.Encode() sorts by key, so entries captured with the same
+		// params in a different order still group together.
+		key := fmt.Sprintf("%s %s?%s", entry.Request.Method, u.Path, query.Encode())
+		if cfg, ok := responseMap[key]; ok {
+			cfg.Responses = append(cfg.Responses, resp)
+			continue
+		}
+
+		var queryMatch map[string]string
+		if len(query) > 0 {
+			queryMatch = make(map[string]string, len(query))
+			for k, v := range query {
+				queryMatch[k] = v[0]
+			}
+		}
+		responseMap[key] = &mockhttpclient.ResponseConfig{
+			Matcher: &mockhttpclient.RequestMatcher{
+				Method:      entry.Request.Method,
+				PathPattern: u.Path,
+				QueryMatch:  queryMatch,
+			},
+			Responses: []*http.Response{resp},
+		}
+	}
+
+	return mockhttpclient.NewMockHTTPClient(responseMap)
+}
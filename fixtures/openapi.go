@@ -0,0 +1,207 @@
+/*
+   Copyright 2021, Subba Reddy Veeramreddy
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/subbuv26/mockhttpclient"
+)
+
+// openAPISpec is the subset of an OpenAPI 3.x document this loader
+// understands: paths, their operations, and the first documented response's
+// example per operation.
+type openAPISpec struct {
+	Paths map[string]map[string]openAPIOperation `json:"paths"`
+}
+
+type openAPIOperation struct {
+	Responses map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIResponse struct {
+	Content map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Example  json.RawMessage           `json:"example"`
+	Examples map[string]openAPIExample `json:"examples"`
+}
+
+type openAPIExample struct {
+	Value json.RawMessage `json:"value"`
+}
+
+var httpMethods = map[string]bool{
+	http.MethodGet: true, http.MethodPost: true, http.MethodPut: true,
+	http.MethodPatch: true, http.MethodDelete: true, http.MethodHead: true,
+	http.MethodOptions: true,
+}
+
+// NewMockHTTPClientFromOpenAPI builds a mock client that replies to each
+// operation in an OpenAPI 3.x JSON document with the first example found in
+// its response, synthesizing a *http.Response from it. Path templates like
+// "/users/{id}" are compiled to a RequestMatcher.PathRegex so any concrete
+// id matches.
+func NewMockHTTPClientFromOpenAPI(path string, opts ...mockhttpclient.Option) (*http.Client, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fixtures: reading OpenAPI spec: %w", err)
+	}
+
+	var spec openAPISpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("fixtures: parsing OpenAPI spec: %w", err)
+	}
+
+	responseMap := make(mockhttpclient.ResponseConfigMap)
+	for template, operations := range spec.Paths {
+		pathRegex, err := compilePathTemplate(template)
+		if err != nil {
+			return nil, fmt.Errorf("fixtures: compiling path %q: %w", template, err)
+		}
+
+		for method, op := range operations {
+			method = strings.ToUpper(method)
+			if !httpMethods[method] {
+				continue
+			}
+
+			resp, err := firstExampleResponse(op)
+			if err != nil {
+				return nil, fmt.Errorf("fixtures: %s %s: %w", method, template, err)
+			}
+
+			key := fmt.Sprintf("%s %s", method, template)
+			responseMap[key] = &mockhttpclient.ResponseConfig{
+				Matcher: &mockhttpclient.RequestMatcher{
+					Method:    method,
+					PathRegex: pathRegex,
+				},
+				Responses: []*http.Response{resp},
+			}
+		}
+	}
+
+	return mockhttpclient.NewMockHTTPClient(responseMap, opts...)
+}
+
+// preferredContentTypes lists content types tried, in order, before falling
+// back to whatever other type sorts first alphabetically. This keeps
+// fixture generation deterministic across runs even when an operation
+// documents more than one content type or named example.
+var preferredContentTypes = []string{"application/json"}
+
+// firstExampleResponse picks the lowest status code with a documented
+// example and builds a *http.Response from it. Content types and example
+// names are tried in a fixed, deterministic order so the same spec always
+// synthesizes the same fixture.
+func firstExampleResponse(op openAPIOperation) (*http.Response, error) {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		content := op.Responses[code].Content
+
+		contentTypes := make([]string, 0, len(content))
+		for ct := range content {
+			contentTypes = append(contentTypes, ct)
+		}
+		sort.Strings(contentTypes)
+		contentTypes = preferContentTypes(contentTypes, preferredContentTypes)
+
+		for _, ct := range contentTypes {
+			body := exampleBody(content[ct])
+			if body == nil {
+				continue
+			}
+			status, err := strconv.Atoi(code)
+			if err != nil {
+				status = http.StatusOK
+			}
+			return mockhttpclient.NewResponseBuilder().Status(status).JSON(json.RawMessage(body)).Build(), nil
+		}
+	}
+	return nil, fmt.Errorf("no response example documented")
+}
+
+// exampleBody returns media's example, falling back to its lowest-named
+// example if Example itself isn't set.
+func exampleBody(media openAPIMediaType) json.RawMessage {
+	if media.Example != nil {
+		return media.Example
+	}
+	names := make([]string, 0, len(media.Examples))
+	for name := range media.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		return media.Examples[name].Value
+	}
+	return nil
+}
+
+// preferContentTypes reorders sorted so any entries also present in
+// preferred come first, in preferred's order, followed by the rest of
+// sorted unchanged.
+func preferContentTypes(sorted, preferred []string) []string {
+	set := make(map[string]bool, len(sorted))
+	for _, ct := range sorted {
+		set[ct] = true
+	}
+
+	ordered := make([]string, 0, len(sorted))
+	seen := make(map[string]bool, len(sorted))
+	for _, ct := range preferred {
+		if set[ct] && !seen[ct] {
+			ordered = append(ordered, ct)
+			seen[ct] = true
+		}
+	}
+	for _, ct := range sorted {
+		if !seen[ct] {
+			ordered = append(ordered, ct)
+			seen[ct] = true
+		}
+	}
+	return ordered
+}
+
+// compilePathTemplate turns an OpenAPI path template such as
+// "/users/{id}/orders/{orderId}" into a regex matching any concrete path.
+func compilePathTemplate(template string) (*regexp.Regexp, error) {
+	segments := strings.Split(template, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			segments[i] = "[^/]+"
+		} else {
+			segments[i] = regexp.QuoteMeta(seg)
+		}
+	}
+	return regexp.Compile("^" + strings.Join(segments, "/") + "$")
+}
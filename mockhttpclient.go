@@ -0,0 +1,283 @@
+/*
+   Copyright 2021, Subba Reddy Veeramreddy
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package mockhttpclient provides an *http.Client backed by a configurable
+// mock transport, so code that talks HTTP can be tested without a real
+// server.
+package mockhttpclient
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestMatcher describes how an incoming *http.Request is paired with a
+// ResponseConfig when dispatch needs to be more specific than the request
+// method alone. A zero-value field is ignored, so callers only need to set
+// the criteria that matter for a given response.
+type RequestMatcher struct {
+	// Method, if set, must equal req.Method.
+	Method string
+
+	// PathPattern, if set, is matched against req.URL.Path. A trailing "*"
+	// matches any path sharing that prefix; otherwise the match is exact.
+	PathPattern string
+
+	// PathRegex, if set, is matched against req.URL.Path.
+	PathRegex *regexp.Regexp
+
+	// HeaderMatch, if set, requires every key/value pair to be present on
+	// the request headers.
+	HeaderMatch map[string]string
+
+	// QueryMatch, if set, requires every key/value pair to be present in
+	// the request's query string.
+	QueryMatch map[string]string
+
+	// BodyMatch, if set, is called with the request body and must return
+	// true for the request to match.
+	BodyMatch func([]byte) bool
+}
+
+// matches reports whether a request with the given method, path, header,
+// query and body satisfies m. It's expressed over plain values, rather than
+// *http.Request, so it can be reused against both live requests and
+// RecordedCall snapshots.
+func (m *RequestMatcher) matches(method, path string, header http.Header, query url.Values, body []byte) bool {
+	if m == nil {
+		return false
+	}
+	if m.Method != "" && !strings.EqualFold(m.Method, method) {
+		return false
+	}
+	if m.PathPattern != "" && !matchPathPattern(m.PathPattern, path) {
+		return false
+	}
+	if m.PathRegex != nil && !m.PathRegex.MatchString(path) {
+		return false
+	}
+	for k, v := range m.HeaderMatch {
+		if header.Get(k) != v {
+			return false
+		}
+	}
+	for k, v := range m.QueryMatch {
+		if query.Get(k) != v {
+			return false
+		}
+	}
+	if m.BodyMatch != nil && !m.BodyMatch(body) {
+		return false
+	}
+	return true
+}
+
+// matchPathPattern matches path against pattern, treating a trailing "*" as
+// a prefix wildcard and anything else as an exact match.
+func matchPathPattern(pattern, path string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(path, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == path
+}
+
+// ResponseConfig holds the queue of responses served for a single dispatch
+// key in a ResponseConfigMap.
+type ResponseConfig struct {
+	// Responses is the ordered list of responses to serve. Once MaxRun
+	// calls have been made, subsequent calls fail. The list is cycled
+	// over with modulo arithmetic, so MaxRun may exceed len(Responses).
+	Responses []*http.Response
+
+	// MaxRun caps the number of requests this config will serve. If left
+	// at zero, it defaults to len(Responses) (i.e. the list is served
+	// exactly once), unless ResponseFunc or Error is set, in which case
+	// calls are unlimited.
+	MaxRun int
+
+	// Matcher, if set, is consulted before falling back to method-only
+	// dispatch; it lets a single ResponseConfigMap carry several configs
+	// for the same HTTP method distinguished by path, headers, etc.
+	Matcher *RequestMatcher
+
+	// ResponseFunc, if set, computes the response from the actual request
+	// instead of serving from Responses. It takes precedence over
+	// Responses. When set and MaxRun is left at zero, calls are
+	// unlimited.
+	ResponseFunc ResponseFunc
+
+	// Error, if set, is returned instead of a response for every call to
+	// this config. Use it to simulate a transport-level failure (a
+	// connection reset, timeout, or DNS error via *net.OpError/*url.Error)
+	// rather than an HTTP-level error response.
+	Error error
+
+	// Delay, if set, is waited out before serving each response or Error.
+	// The wait is cancelled early if the request's context is done.
+	Delay time.Duration
+
+	// DelayFunc, if set, takes precedence over Delay and computes the
+	// wait from the 1-indexed call attempt, so a config can simulate
+	// increasing latency or a timeout that only hits on a later retry.
+	DelayFunc func(attempt int) time.Duration
+
+	mu    sync.Mutex
+	count int
+}
+
+// ResponseConfigMap maps a dispatch key to the responses served for it. The
+// conventional key is the HTTP method (e.g. http.MethodGet), but any unique
+// string may be used for entries that carry a Matcher.
+type ResponseConfigMap map[string]*ResponseConfig
+
+// validate checks that every entry in the map is usable, defaulting MaxRun
+// where necessary.
+func (m ResponseConfigMap) validate() error {
+	if m == nil {
+		return fmt.Errorf("mockhttpclient: responseMap must not be nil")
+	}
+	for key, cfg := range m {
+		hasResponses := cfg != nil && (cfg.ResponseFunc != nil || cfg.Error != nil || len(cfg.Responses) > 0)
+		if !hasResponses {
+			return fmt.Errorf("mockhttpclient: no responses configured for %q", key)
+		}
+		if cfg.MaxRun < 0 {
+			return fmt.Errorf("mockhttpclient: invalid MaxRun %d for %q", cfg.MaxRun, key)
+		}
+		if cfg.MaxRun == 0 && cfg.ResponseFunc == nil && cfg.Error == nil {
+			cfg.MaxRun = len(cfg.Responses)
+		}
+	}
+	return nil
+}
+
+// next returns the next response to serve for cfg, or an error if cfg has
+// already served MaxRun responses. A MaxRun of zero alongside a
+// ResponseFunc or Error means calls are unlimited.
+func (c *ResponseConfig) next(key string, req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	if c.MaxRun > 0 && c.count >= c.MaxRun {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("mockhttpclient: exceeded MaxRun (%d) for %q", c.MaxRun, key)
+	}
+	c.count++
+	attempt := c.count
+	delay := c.Delay
+	if c.DelayFunc != nil {
+		delay = c.DelayFunc(attempt)
+	}
+	c.mu.Unlock()
+
+	if delay > 0 {
+		if err := waitOrCancel(req, delay); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Error != nil {
+		return nil, c.Error
+	}
+
+	if c.ResponseFunc != nil {
+		return c.ResponseFunc(req)
+	}
+
+	resp := c.Responses[(attempt-1)%len(c.Responses)]
+	if resp == nil {
+		return nil, fmt.Errorf("mockhttpclient: nil response configured for %q", key)
+	}
+	return resp, nil
+}
+
+// NewMockHTTPClient returns an *http.Client whose Transport serves the
+// responses configured in responseMap. Requests are dispatched first to any
+// entry whose Matcher matches the request, falling back to the entry keyed
+// by req.Method for backward compatibility with method-only configuration.
+// Every request is captured by a CallRecorder retrievable via Recorder, and
+// opts may enable behaviors such as WithStrictMode.
+func NewMockHTTPClient(responseMap ResponseConfigMap, opts ...Option) (*http.Client, error) {
+	if err := responseMap.validate(); err != nil {
+		return nil, err
+	}
+	tr := &mockTransport{responseMap: responseMap, recorder: &CallRecorder{}}
+	for _, opt := range opts {
+		opt(tr)
+	}
+	return &http.Client{Transport: tr}, nil
+}
+
+// mockTransport is the http.RoundTripper backing the clients returned by
+// NewMockHTTPClient.
+type mockTransport struct {
+	responseMap ResponseConfigMap
+	recorder    *CallRecorder
+}
+
+func (t *mockTransport) callRecorder() *CallRecorder { return t.recorder }
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = ioutil.ReadAll(req.Body)
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	key, cfg, err := t.resolve(req, body)
+	t.recorder.record(RecordedCall{
+		Method:  req.Method,
+		URL:     req.URL,
+		Header:  req.Header.Clone(),
+		Body:    body,
+		Matched: err == nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cfg.next(key, req)
+}
+
+// resolve picks the ResponseConfig that should serve req: the first
+// matcher-bearing config (in a stable, sorted-by-key order) that matches,
+// otherwise the config keyed by req.Method.
+func (t *mockTransport) resolve(req *http.Request, body []byte) (string, *ResponseConfig, error) {
+	keys := make([]string, 0, len(t.responseMap))
+	for key := range t.responseMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	query := req.URL.Query()
+	for _, key := range keys {
+		cfg := t.responseMap[key]
+		if cfg.Matcher != nil && cfg.Matcher.matches(req.Method, req.URL.Path, req.Header, query, body) {
+			return key, cfg, nil
+		}
+	}
+
+	if cfg, ok := t.responseMap[req.Method]; ok && cfg.Matcher == nil {
+		return req.Method, cfg, nil
+	}
+
+	return "", nil, fmt.Errorf("mockhttpclient: no response configured for %s %s", req.Method, req.URL.Path)
+}
@@ -201,4 +201,86 @@ var _ = Describe("Mock HTTP Client Tests", func() {
 			Expect(resp).To(BeNil())
 		})
 	})
+
+	Describe("Matching Requests by URL/Path", func() {
+		var responseMap ResponseConfigMap
+		var client *http.Client
+		var resp1, resp2 *http.Response
+
+		BeforeEach(func() {
+			resp1 = &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("user"))),
+			}
+			resp2 = &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("ok"))),
+			}
+			responseMap = make(ResponseConfigMap)
+		})
+
+		It("Dispatches by exact path match", func() {
+			responseMap["get-user"] = &ResponseConfig{
+				Matcher:   &RequestMatcher{Method: http.MethodGet, PathPattern: "/users/1"},
+				Responses: []*http.Response{resp1},
+			}
+			responseMap["get-health"] = &ResponseConfig{
+				Matcher:   &RequestMatcher{Method: http.MethodGet, PathPattern: "/health"},
+				Responses: []*http.Response{resp2},
+			}
+			client, _ = NewMockHTTPClient(responseMap)
+
+			userReq, _ := http.NewRequest("GET", "http://1.2.3.4/users/1", nil)
+			resp, err := client.Do(userReq)
+			Expect(err).To(BeNil())
+			Expect(resp).To(Equal(resp1))
+
+			healthReq, _ := http.NewRequest("GET", "http://1.2.3.4/health", nil)
+			resp, err = client.Do(healthReq)
+			Expect(err).To(BeNil())
+			Expect(resp).To(Equal(resp2))
+		})
+
+		It("Dispatches by path prefix", func() {
+			responseMap["users"] = &ResponseConfig{
+				Matcher:   &RequestMatcher{Method: http.MethodGet, PathPattern: "/users/*"},
+				Responses: []*http.Response{resp1},
+			}
+			client, _ = NewMockHTTPClient(responseMap)
+
+			req, _ := http.NewRequest("GET", "http://1.2.3.4/users/42", nil)
+			resp, err := client.Do(req)
+			Expect(err).To(BeNil())
+			Expect(resp).To(Equal(resp1))
+		})
+
+		It("Falls back to method-only dispatch when no matcher matches", func() {
+			responseMap[http.MethodGet] = &ResponseConfig{Responses: []*http.Response{resp2}}
+			responseMap["users"] = &ResponseConfig{
+				Matcher:   &RequestMatcher{Method: http.MethodGet, PathPattern: "/users/1"},
+				Responses: []*http.Response{resp1},
+			}
+			client, _ = NewMockHTTPClient(responseMap)
+
+			req, _ := http.NewRequest("GET", "http://1.2.3.4/anything", nil)
+			resp, err := client.Do(req)
+			Expect(err).To(BeNil())
+			Expect(resp).To(Equal(resp2))
+		})
+
+		It("Fails when nothing matches and no fallback exists", func() {
+			responseMap["users"] = &ResponseConfig{
+				Matcher:   &RequestMatcher{Method: http.MethodGet, PathPattern: "/users/1"},
+				Responses: []*http.Response{resp1},
+			}
+			client, _ = NewMockHTTPClient(responseMap)
+
+			req, _ := http.NewRequest("GET", "http://1.2.3.4/anything", nil)
+			resp, err := client.Do(req)
+			Expect(err).NotTo(BeNil())
+			Expect(resp).To(BeNil())
+		})
+	})
 })
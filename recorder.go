@@ -0,0 +1,147 @@
+/*
+   Copyright 2021, Subba Reddy Veeramreddy
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package mockhttpclient
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// TestingT is the subset of *testing.T used for recorder assertions, kept
+// narrow so callers can pass *testing.T, *testing.B, or a ginkgo/gomega
+// GinkgoT() without an adapter.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// CleanupT additionally supports registering teardown callbacks, as
+// *testing.T does via Cleanup.
+type CleanupT interface {
+	TestingT
+	Cleanup(func())
+}
+
+// RecordedCall is a snapshot of a single request passed to a mock client's
+// Do method.
+type RecordedCall struct {
+	Method  string
+	URL     *url.URL
+	Header  http.Header
+	Body    []byte
+	Matched bool
+}
+
+// CallRecorder captures every request a mock client receives so tests can
+// assert on what was actually sent.
+type CallRecorder struct {
+	mu    sync.Mutex
+	calls []RecordedCall
+}
+
+func (r *CallRecorder) record(call RecordedCall) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// Calls returns every request recorded so far, in call order.
+func (r *CallRecorder) Calls() []RecordedCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]RecordedCall, len(r.calls))
+	copy(out, r.calls)
+	return out
+}
+
+// CallsMatching returns the recorded calls satisfying m.
+func (r *CallRecorder) CallsMatching(m RequestMatcher) []RecordedCall {
+	var out []RecordedCall
+	for _, call := range r.Calls() {
+		if m.matches(call.Method, call.URL.Path, call.Header, call.URL.Query(), call.Body) {
+			out = append(out, call)
+		}
+	}
+	return out
+}
+
+// AssertCalled fails t unless exactly times recorded calls satisfy m.
+func (r *CallRecorder) AssertCalled(t TestingT, m RequestMatcher, times int) {
+	t.Helper()
+	if n := len(r.CallsMatching(m)); n != times {
+		t.Errorf("mockhttpclient: expected %d call(s) matching %+v, got %d", times, m, n)
+	}
+}
+
+// AssertNoUnmatchedCalls fails t if any recorded request matched no
+// configured response. A request that matched a config which then returned
+// an error (e.g. its MaxRun was exceeded) counts as matched.
+func (r *CallRecorder) AssertNoUnmatchedCalls(t TestingT) {
+	t.Helper()
+	for _, call := range r.Calls() {
+		if !call.Matched {
+			t.Errorf("mockhttpclient: unmatched request %s %s", call.Method, call.URL)
+		}
+	}
+}
+
+// Option configures a client returned by NewMockHTTPClient.
+type Option func(*mockTransport)
+
+// WithStrictMode registers a t.Cleanup callback that fails t if any
+// configured ResponseConfig was never fully consumed, analogous to gock's
+// IsDone/HasUnmatchedRequest checks.
+func WithStrictMode(t CleanupT) Option {
+	return func(tr *mockTransport) {
+		t.Cleanup(func() {
+			tr.assertDone(t)
+		})
+	}
+}
+
+// assertDone fails t for every ResponseConfig that hasn't served MaxRun
+// responses yet.
+func (t *mockTransport) assertDone(tt TestingT) {
+	tt.Helper()
+	for key, cfg := range t.responseMap {
+		cfg.mu.Lock()
+		done := cfg.count >= cfg.MaxRun
+		count, maxRun := cfg.count, cfg.MaxRun
+		cfg.mu.Unlock()
+		if !done {
+			tt.Errorf("mockhttpclient: %q only served %d/%d configured responses", key, count, maxRun)
+		}
+	}
+}
+
+// recorderHolder is implemented by every RoundTripper this package hands
+// out, so Recorder works regardless of which constructor built the client.
+type recorderHolder interface {
+	callRecorder() *CallRecorder
+}
+
+// Recorder returns the CallRecorder attached to a client built by this
+// package (NewMockHTTPClient, NewMockHTTPClientFromScenario, ...), or nil if
+// client wasn't built by it.
+func Recorder(client *http.Client) *CallRecorder {
+	rh, ok := client.Transport.(recorderHolder)
+	if !ok {
+		return nil
+	}
+	return rh.callRecorder()
+}
@@ -0,0 +1,127 @@
+/*
+   Copyright 2021, Subba Reddy Veeramreddy
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package mockhttpclient
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeT is a minimal TestingT/CleanupT used to assert on recorder failures
+// without depending on a real *testing.T inside the ginkgo suite.
+type fakeT struct {
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeT) Helper()                                {}
+func (f *fakeT) Errorf(format string, a ...interface{}) { f.errors = append(f.errors, format) }
+func (f *fakeT) Cleanup(fn func())                      { f.cleanups = append(f.cleanups, fn) }
+
+var _ = Describe("Call Recorder", func() {
+	var responseMap ResponseConfigMap
+	var client *http.Client
+	var resp1 *http.Response
+
+	BeforeEach(func() {
+		resp1 = &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte("body"))),
+		}
+		responseMap = make(ResponseConfigMap)
+	})
+
+	It("Records every call made through the client", func() {
+		responseMap[http.MethodPost] = &ResponseConfig{Responses: []*http.Response{resp1, resp1}}
+		client, _ = NewMockHTTPClient(responseMap)
+
+		req, _ := http.NewRequest("POST", "http://1.2.3.4/login", bytes.NewBufferString(`{"u":"a"}`))
+		_, _ = client.Do(req)
+		_, _ = client.Do(req)
+
+		calls := Recorder(client).Calls()
+		Expect(calls).To(HaveLen(2))
+		Expect(calls[0].Method).To(Equal("POST"))
+		Expect(string(calls[0].Body)).To(Equal(`{"u":"a"}`))
+	})
+
+	It("Tees the request body so it remains readable after Do", func() {
+		responseMap[http.MethodPost] = &ResponseConfig{Responses: []*http.Response{resp1}}
+		client, _ = NewMockHTTPClient(responseMap)
+
+		req, _ := http.NewRequest("POST", "http://1.2.3.4/login", bytes.NewBufferString("payload"))
+		_, _ = client.Do(req)
+
+		remaining, _ := ioutil.ReadAll(req.Body)
+		Expect(string(remaining)).To(Equal("payload"))
+	})
+
+	It("Filters calls matching a RequestMatcher", func() {
+		responseMap[http.MethodGet] = &ResponseConfig{Responses: []*http.Response{resp1, resp1}}
+		client, _ = NewMockHTTPClient(responseMap)
+
+		req1, _ := http.NewRequest("GET", "http://1.2.3.4/users/1", nil)
+		req2, _ := http.NewRequest("GET", "http://1.2.3.4/health", nil)
+		_, _ = client.Do(req1)
+		_, _ = client.Do(req2)
+
+		matches := Recorder(client).CallsMatching(RequestMatcher{PathPattern: "/users/1"})
+		Expect(matches).To(HaveLen(1))
+	})
+
+	It("AssertCalled fails when the expected count doesn't match", func() {
+		responseMap[http.MethodGet] = &ResponseConfig{Responses: []*http.Response{resp1}}
+		client, _ = NewMockHTTPClient(responseMap)
+
+		req, _ := http.NewRequest("GET", "http://1.2.3.4/health", nil)
+		_, _ = client.Do(req)
+
+		t := &fakeT{}
+		Recorder(client).AssertCalled(t, RequestMatcher{PathPattern: "/health"}, 2)
+		Expect(t.errors).To(HaveLen(1))
+	})
+
+	It("AssertNoUnmatchedCalls reports requests that had no configured response", func() {
+		responseMap[http.MethodGet] = &ResponseConfig{Responses: []*http.Response{resp1}}
+		client, _ = NewMockHTTPClient(responseMap)
+
+		req, _ := http.NewRequest("DELETE", "http://1.2.3.4/health", nil)
+		_, _ = client.Do(req)
+
+		t := &fakeT{}
+		Recorder(client).AssertNoUnmatchedCalls(t)
+		Expect(t.errors).To(HaveLen(1))
+	})
+
+	It("WithStrictMode fails the test at cleanup if a response was never consumed", func() {
+		responseMap[http.MethodGet] = &ResponseConfig{Responses: []*http.Response{resp1, resp1}}
+		t := &fakeT{}
+		client, _ = NewMockHTTPClient(responseMap, WithStrictMode(t))
+
+		req, _ := http.NewRequest("GET", "http://1.2.3.4/health", nil)
+		_, _ = client.Do(req)
+
+		Expect(t.cleanups).To(HaveLen(1))
+		t.cleanups[0]()
+		Expect(t.errors).To(HaveLen(1))
+	})
+})
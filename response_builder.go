@@ -0,0 +1,110 @@
+/*
+   Copyright 2021, Subba Reddy Veeramreddy
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package mockhttpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// ResponseFunc computes a response from the actual *http.Request, letting a
+// ResponseConfig echo headers, reflect the request body, or vary the status
+// based on the request instead of pre-baking every *http.Response.
+type ResponseFunc func(*http.Request) (*http.Response, error)
+
+// ResponseBuilder builds fresh *http.Response values on demand. Unlike a
+// bare *http.Response, each call to Build returns a response with a new
+// ioutil.NopCloser body, so the same builder can safely back retried
+// requests whose earlier response bodies have already been drained.
+type ResponseBuilder struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	err        error
+}
+
+// NewResponseBuilder returns a ResponseBuilder defaulting to a 200 status
+// and an empty body.
+func NewResponseBuilder() *ResponseBuilder {
+	return &ResponseBuilder{statusCode: http.StatusOK, header: http.Header{}}
+}
+
+// Status sets the response status code.
+func (b *ResponseBuilder) Status(code int) *ResponseBuilder {
+	b.statusCode = code
+	return b
+}
+
+// Header sets a response header.
+func (b *ResponseBuilder) Header(key, value string) *ResponseBuilder {
+	b.header.Set(key, value)
+	return b
+}
+
+// BodyString sets the response body to s.
+func (b *ResponseBuilder) BodyString(s string) *ResponseBuilder {
+	b.body = []byte(s)
+	return b
+}
+
+// JSON marshals v and uses it as the response body, also setting the
+// Content-Type header to "application/json" if it isn't already set. A
+// marshal failure is a programming error in the fixture, not a runtime
+// condition to paper over, so it's surfaced by Build (which panics) rather
+// than serialized into the body.
+func (b *ResponseBuilder) JSON(v interface{}) *ResponseBuilder {
+	data, err := json.Marshal(v)
+	if err != nil {
+		b.err = fmt.Errorf("mockhttpclient: ResponseBuilder.JSON: %w", err)
+		return b
+	}
+	if b.header.Get("Content-Type") == "" {
+		b.header.Set("Content-Type", "application/json")
+	}
+	b.body = data
+	return b
+}
+
+// Build returns a *http.Response reflecting the builder's current state.
+// Every call returns a distinct Body reader, so the same builder may be
+// used to serve the same response more than once. It panics if an earlier
+// call to JSON failed to marshal its argument.
+func (b *ResponseBuilder) Build() *http.Response {
+	if b.err != nil {
+		panic(b.err)
+	}
+
+	header := make(http.Header, len(b.header))
+	for k, v := range b.header {
+		header[k] = append([]string(nil), v...)
+	}
+	return &http.Response{
+		StatusCode: b.statusCode,
+		Header:     header,
+		Body:       ioutil.NopCloser(bytes.NewReader(b.body)),
+	}
+}
+
+// ToResponseFunc adapts the builder to a ResponseFunc, ignoring the request.
+func (b *ResponseBuilder) ToResponseFunc() ResponseFunc {
+	return func(*http.Request) (*http.Response, error) {
+		return b.Build(), nil
+	}
+}
@@ -0,0 +1,117 @@
+/*
+   Copyright 2021, Subba Reddy Veeramreddy
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package mockhttpclient
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Programmable Responses", func() {
+	var responseMap ResponseConfigMap
+	var client *http.Client
+
+	BeforeEach(func() {
+		responseMap = make(ResponseConfigMap)
+	})
+
+	Describe("ResponseFunc", func() {
+		It("Computes a response from the request", func() {
+			responseMap[http.MethodGet] = &ResponseConfig{
+				ResponseFunc: func(req *http.Request) (*http.Response, error) {
+					return NewResponseBuilder().Status(200).BodyString(req.URL.Path).Build(), nil
+				},
+			}
+			client, _ = NewMockHTTPClient(responseMap)
+
+			req, _ := http.NewRequest("GET", "http://1.2.3.4/ping", nil)
+			resp, err := client.Do(req)
+			Expect(err).To(BeNil())
+			body, _ := ioutil.ReadAll(resp.Body)
+			Expect(string(body)).To(Equal("/ping"))
+		})
+
+		It("Allows unlimited calls when MaxRun is unset", func() {
+			responseMap[http.MethodGet] = &ResponseConfig{
+				ResponseFunc: func(req *http.Request) (*http.Response, error) {
+					return NewResponseBuilder().Build(), nil
+				},
+			}
+			client, _ = NewMockHTTPClient(responseMap)
+
+			req, _ := http.NewRequest("GET", "http://1.2.3.4/ping", nil)
+			for i := 0; i < 5; i++ {
+				resp, err := client.Do(req)
+				Expect(err).To(BeNil())
+				Expect(resp).NotTo(BeNil())
+			}
+		})
+
+		It("Honors an explicit MaxRun", func() {
+			responseMap[http.MethodGet] = &ResponseConfig{
+				MaxRun: 1,
+				ResponseFunc: func(req *http.Request) (*http.Response, error) {
+					return NewResponseBuilder().Build(), nil
+				},
+			}
+			client, _ = NewMockHTTPClient(responseMap)
+
+			req, _ := http.NewRequest("GET", "http://1.2.3.4/ping", nil)
+			_, err := client.Do(req)
+			Expect(err).To(BeNil())
+			_, err = client.Do(req)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("ResponseBuilder", func() {
+		It("Builds a response with status, header and body", func() {
+			resp := NewResponseBuilder().Status(201).Header("X-Test", "yes").BodyString("created").Build()
+			Expect(resp.StatusCode).To(Equal(201))
+			Expect(resp.Header.Get("X-Test")).To(Equal("yes"))
+			body, _ := ioutil.ReadAll(resp.Body)
+			Expect(string(body)).To(Equal("created"))
+		})
+
+		It("Builds a JSON response", func() {
+			resp := NewResponseBuilder().JSON(map[string]string{"hello": "world"})
+			Expect(resp.Build().Header.Get("Content-Type")).To(Equal("application/json"))
+			body, _ := ioutil.ReadAll(resp.Build().Body)
+			Expect(string(body)).To(Equal(`{"hello":"world"}`))
+		})
+
+		It("Panics on Build when JSON failed to marshal its argument", func() {
+			builder := NewResponseBuilder().JSON(make(chan int))
+			Expect(func() { builder.Build() }).To(Panic())
+		})
+
+		It("Produces a fresh body on every Build call, so it survives retries", func() {
+			builder := NewResponseBuilder().BodyString("retry-me")
+			first := builder.Build()
+			second := builder.Build()
+
+			firstBody, _ := ioutil.ReadAll(first.Body)
+			Expect(string(firstBody)).To(Equal("retry-me"))
+
+			secondBody, _ := ioutil.ReadAll(second.Body)
+			Expect(string(secondBody)).To(Equal("retry-me"))
+		})
+	})
+})
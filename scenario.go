@@ -0,0 +1,208 @@
+/*
+   Copyright 2021, Subba Reddy Veeramreddy
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package mockhttpclient
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// scenarioStep is one entry in a Scenario's script: a request matcher
+// paired with the response served once that step is reached.
+type scenarioStep struct {
+	method   string
+	path     string
+	matcher  RequestMatcher
+	response *http.Response
+}
+
+// Scenario scripts a sequenced, stateful flow across multiple methods and
+// paths (e.g. "POST /login, then GET /me, then GET /me again with a
+// different result"), as opposed to the per-method queues a
+// ResponseConfigMap serves independently of each other.
+type Scenario struct {
+	steps       []*scenarioStep
+	current     *scenarioStep
+	strictOrder bool
+	fallback    ResponseConfigMap
+}
+
+// NewScenario starts an empty script. Steps are always served in order;
+// without WithStrictOrder, a request that doesn't match the next step is
+// passed to the fallback set via WithFallback instead of failing.
+func NewScenario() *Scenario {
+	return &Scenario{}
+}
+
+// On appends a new step expecting a request matching method and path, and
+// becomes the target of the following Reply call.
+func (s *Scenario) On(method, path string) *Scenario {
+	s.current = &scenarioStep{
+		method:  method,
+		path:    path,
+		matcher: RequestMatcher{Method: method, PathPattern: path},
+	}
+	s.steps = append(s.steps, s.current)
+	return s
+}
+
+// Then is an alias for On, read naturally when chaining steps:
+// NewScenario().On("POST", "/login").Reply(r1).Then("GET", "/me").Reply(r2).
+func (s *Scenario) Then(method, path string) *Scenario {
+	return s.On(method, path)
+}
+
+// Reply sets the response served for the step started by the preceding On
+// or Then call.
+func (s *Scenario) Reply(resp *http.Response) *Scenario {
+	if s.current != nil {
+		s.current.response = resp
+	}
+	return s
+}
+
+// WithStrictOrder makes requests that don't match the next expected step
+// fail outright, instead of falling through to the fallback
+// ResponseConfigMap set via WithFallback.
+func (s *Scenario) WithStrictOrder() *Scenario {
+	s.strictOrder = true
+	return s
+}
+
+// WithFallback sets the ResponseConfigMap consulted for requests that don't
+// match the next expected step (ignored once WithStrictOrder is set).
+func (s *Scenario) WithFallback(responseMap ResponseConfigMap) *Scenario {
+	s.fallback = responseMap
+	return s
+}
+
+func (s *Scenario) validate() error {
+	if len(s.steps) == 0 {
+		return fmt.Errorf("mockhttpclient: scenario has no steps")
+	}
+	for i, step := range s.steps {
+		if step.response == nil {
+			return fmt.Errorf("mockhttpclient: scenario step %d (%s %s) has no Reply", i, step.method, step.path)
+		}
+	}
+	return nil
+}
+
+// NewMockHTTPClientFromScenario returns an *http.Client that walks
+// scenario's steps in order, one per matching request. A request that
+// doesn't match the next expected step either fails (WithStrictOrder) or
+// falls through to the scenario's fallback ResponseConfigMap.
+func NewMockHTTPClientFromScenario(scenario *Scenario) (*http.Client, error) {
+	if err := scenario.validate(); err != nil {
+		return nil, err
+	}
+
+	var fallback *mockTransport
+	if scenario.fallback != nil {
+		if err := scenario.fallback.validate(); err != nil {
+			return nil, err
+		}
+		fallback = &mockTransport{responseMap: scenario.fallback, recorder: &CallRecorder{}}
+	}
+
+	tr := &scenarioTransport{
+		steps:       scenario.steps,
+		strictOrder: scenario.strictOrder,
+		fallback:    fallback,
+		recorder:    &CallRecorder{},
+	}
+	return &http.Client{Transport: tr}, nil
+}
+
+// scenarioTransport is the http.RoundTripper backing clients returned by
+// NewMockHTTPClientFromScenario.
+type scenarioTransport struct {
+	mu          sync.Mutex
+	steps       []*scenarioStep
+	cursor      int
+	strictOrder bool
+	fallback    *mockTransport
+	recorder    *CallRecorder
+}
+
+func (t *scenarioTransport) callRecorder() *CallRecorder { return t.recorder }
+
+func (t *scenarioTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = ioutil.ReadAll(req.Body)
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, matched, err := t.dispatch(req, body)
+	t.recorder.record(RecordedCall{
+		Method:  req.Method,
+		URL:     req.URL,
+		Header:  req.Header.Clone(),
+		Body:    body,
+		Matched: matched,
+	})
+	return resp, err
+}
+
+// dispatch serves req and reports whether it matched a scenario step or a
+// fallback ResponseConfig, even if that config then returned an error.
+func (t *scenarioTransport) dispatch(req *http.Request, body []byte) (*http.Response, bool, error) {
+	t.mu.Lock()
+	var step *scenarioStep
+	if t.cursor < len(t.steps) {
+		candidate := t.steps[t.cursor]
+		query := req.URL.Query()
+		if candidate.matcher.matches(req.Method, req.URL.Path, req.Header, query, body) {
+			step = candidate
+			t.cursor++
+		}
+	}
+	t.mu.Unlock()
+
+	if step != nil {
+		return step.response, true, nil
+	}
+
+	if t.strictOrder {
+		return nil, false, t.outOfOrderError(req)
+	}
+
+	if t.fallback != nil {
+		key, cfg, err := t.fallback.resolve(req, body)
+		if err != nil {
+			return nil, false, err
+		}
+		resp, err := cfg.next(key, req)
+		return resp, true, err
+	}
+
+	return nil, false, t.outOfOrderError(req)
+}
+
+func (t *scenarioTransport) outOfOrderError(req *http.Request) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cursor >= len(t.steps) {
+		return fmt.Errorf("mockhttpclient: scenario complete, unexpected %s %s", req.Method, req.URL.Path)
+	}
+	expected := t.steps[t.cursor]
+	return fmt.Errorf("mockhttpclient: scenario expected %s %s, got %s %s", expected.method, expected.path, req.Method, req.URL.Path)
+}
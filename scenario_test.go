@@ -0,0 +1,99 @@
+/*
+   Copyright 2021, Subba Reddy Veeramreddy
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package mockhttpclient
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scenario", func() {
+	loginOK := NewResponseBuilder().Status(200).BodyString("token").Build()
+	meOK := NewResponseBuilder().Status(200).BodyString("me-ok").Build()
+	meExpired := NewResponseBuilder().Status(401).BodyString("expired").Build()
+	refreshOK := NewResponseBuilder().Status(200).BodyString("refreshed").Build()
+
+	It("Walks a multi-step flow across methods and paths in order", func() {
+		scenario := NewScenario().
+			On("POST", "/login").Reply(loginOK).
+			Then("GET", "/me").Reply(meOK).
+			Then("GET", "/me").Reply(meExpired).
+			Then("POST", "/refresh").Reply(refreshOK)
+
+		client, err := NewMockHTTPClientFromScenario(scenario)
+		Expect(err).To(BeNil())
+
+		loginReq, _ := http.NewRequest("POST", "http://1.2.3.4/login", nil)
+		resp, err := client.Do(loginReq)
+		Expect(err).To(BeNil())
+		Expect(resp).To(Equal(loginOK))
+
+		meReq, _ := http.NewRequest("GET", "http://1.2.3.4/me", nil)
+		resp, err = client.Do(meReq)
+		Expect(err).To(BeNil())
+		Expect(resp).To(Equal(meOK))
+
+		resp, err = client.Do(meReq)
+		Expect(err).To(BeNil())
+		Expect(resp).To(Equal(meExpired))
+
+		refreshReq, _ := http.NewRequest("POST", "http://1.2.3.4/refresh", nil)
+		resp, err = client.Do(refreshReq)
+		Expect(err).To(BeNil())
+		Expect(resp).To(Equal(refreshOK))
+	})
+
+	It("Fails a request that skips ahead when WithStrictOrder is set", func() {
+		scenario := NewScenario().
+			On("POST", "/login").Reply(loginOK).
+			Then("GET", "/me").Reply(meOK).
+			WithStrictOrder()
+
+		client, err := NewMockHTTPClientFromScenario(scenario)
+		Expect(err).To(BeNil())
+
+		meReq, _ := http.NewRequest("GET", "http://1.2.3.4/me", nil)
+		resp, err := client.Do(meReq)
+		Expect(err).NotTo(BeNil())
+		Expect(resp).To(BeNil())
+	})
+
+	It("Falls through to the fallback ResponseConfigMap when not strict", func() {
+		fallback := ResponseConfigMap{
+			http.MethodGet: &ResponseConfig{Responses: []*http.Response{meOK}},
+		}
+		scenario := NewScenario().
+			On("POST", "/login").Reply(loginOK).
+			WithFallback(fallback)
+
+		client, err := NewMockHTTPClientFromScenario(scenario)
+		Expect(err).To(BeNil())
+
+		meReq, _ := http.NewRequest("GET", "http://1.2.3.4/me", nil)
+		resp, err := client.Do(meReq)
+		Expect(err).To(BeNil())
+		Expect(resp).To(Equal(meOK))
+	})
+
+	It("Rejects a scenario with a step missing a Reply", func() {
+		scenario := NewScenario().On("POST", "/login")
+		_, err := NewMockHTTPClientFromScenario(scenario)
+		Expect(err).NotTo(BeNil())
+	})
+})